@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"time"
 )
@@ -27,17 +28,42 @@ const (
 type PomodoroTimer struct {
 	mu            sync.RWMutex
 	state         TimerState
+	pausedState   TimerState // phase to resume into while paused
 	remainingTime int // seconds
 	currentCycle  int
 	completedPomodoros int
+	sessionsSinceLongBreak int
+	sessionsToday int
+	focusSecondsToday int
+	workedSeconds int // focus seconds elapsed in the current work session
+	overBudget    bool // current run was started past the daily budget
+	overBudgetSessionsToday int
+	counterDay    string // local date (YYYY-MM-DD) the daily counters belong to
 	ticker        *time.Ticker
-	stopChan      chan bool
+	stopChan      chan bool // closed to stop the current ticking goroutine
 	
 	// Settings
 	workDuration     int // minutes
 	shortBreakDuration int // minutes
 	longBreakDuration  int // minutes
 	longBreakInterval  int // pomodoros before long break
+	dailySessionBudget int // work sessions per day, 0 for no budget
+
+	// Allowed duration range per session type
+	durationBounds map[TimerState]durationBounds
+}
+
+// durationBounds is the allowed range, in minutes, for one session type
+type durationBounds struct {
+	min int
+	max int
+}
+
+// durationNames names each session type's duration in validation errors
+var durationNames = map[TimerState]string{
+	StateWorking:   "work duration",
+	StateBreak:     "short break duration",
+	StateLongBreak: "long break duration",
 }
 
 // TimerStatus represents the current timer status for frontend
@@ -46,6 +72,15 @@ type TimerStatus struct {
 	RemainingTime int        `json:"remainingTime"`
 	CurrentCycle  int        `json:"currentCycle"`
 	CompletedPomodoros int   `json:"completedPomodoros"`
+	SessionsSinceLongBreak int `json:"sessionsSinceLongBreak"`
+	LongBreakInterval int    `json:"longBreakInterval"`
+	LongBreakDue  bool       `json:"longBreakDue"`
+	SessionsToday int        `json:"sessionsToday"`
+	FocusSecondsToday int    `json:"focusSecondsToday"`
+	DailySessionBudget int   `json:"dailySessionBudget"`
+	BudgetReached bool       `json:"budgetReached"`
+	OverBudget    bool       `json:"overBudget"`
+	OverBudgetSessionsToday int `json:"overBudgetSessionsToday"`
 }
 
 // NewApp creates a new App application struct
@@ -62,13 +97,19 @@ func NewPomodoroTimer() *PomodoroTimer {
 		remainingTime:      0,
 		currentCycle:       1,
 		completedPomodoros: 0,
-		stopChan:           make(chan bool),
 		
 		// Default settings
 		workDuration:       25,
 		shortBreakDuration: 5,
 		longBreakDuration:  15,
 		longBreakInterval:  4,
+
+		// Default duration bounds
+		durationBounds: map[TimerState]durationBounds{
+			StateWorking:   {min: 1, max: 240},
+			StateBreak:     {min: 1, max: 60},
+			StateLongBreak: {min: 1, max: 120},
+		},
 	}
 }
 
@@ -78,17 +119,24 @@ func (a *App) startup(ctx context.Context) {
 	a.ctx = ctx
 }
 
-// StartTimer starts a new pomodoro session
-func (a *App) StartTimer() TimerStatus {
+// StartTimer starts a new pomodoro session. Once the daily budget is reached
+// a new session only starts with override set, and is flagged as over budget
+func (a *App) StartTimer(override bool) TimerStatus {
 	a.timer.mu.Lock()
 	defer a.timer.mu.Unlock()
 	
 	if a.timer.state == StateIdle {
+		budgetReached := a.timer.budgetReached(time.Now())
+		if budgetReached && !override {
+			return a.getStatus()
+		}
+		a.timer.overBudget = budgetReached
 		a.timer.state = StateWorking
 		a.timer.remainingTime = a.timer.workDuration * 60 // convert to seconds
 		a.timer.startTicking()
 	} else if a.timer.state == StatePaused {
-		a.timer.state = StateWorking
+		a.timer.state = a.timer.pausedState
+		a.timer.pausedState = ""
 		a.timer.startTicking()
 	}
 	
@@ -101,6 +149,7 @@ func (a *App) PauseTimer() TimerStatus {
 	defer a.timer.mu.Unlock()
 	
 	if a.timer.state == StateWorking || a.timer.state == StateBreak || a.timer.state == StateLongBreak {
+		a.timer.pausedState = a.timer.state
 		a.timer.state = StatePaused
 		a.timer.stopTicking()
 	}
@@ -114,8 +163,11 @@ func (a *App) StopTimer() TimerStatus {
 	defer a.timer.mu.Unlock()
 	
 	a.timer.state = StateIdle
+	a.timer.pausedState = ""
 	a.timer.remainingTime = 0
 	a.timer.currentCycle = 1
+	a.timer.workedSeconds = 0
+	a.timer.overBudget = false
 	a.timer.stopTicking()
 	
 	return a.getStatus()
@@ -130,41 +182,118 @@ func (a *App) GetTimerStatus() TimerStatus {
 
 // getStatus returns the current status (must be called with lock held)
 func (a *App) getStatus() TimerStatus {
-	return TimerStatus{
+	now := time.Now()
+	status := TimerStatus{
 		State:              a.timer.state,
 		RemainingTime:      a.timer.remainingTime,
 		CurrentCycle:       a.timer.currentCycle,
 		CompletedPomodoros: a.timer.completedPomodoros,
+		SessionsSinceLongBreak: a.timer.sessionsSinceLongBreakOn(now),
+		LongBreakInterval:  a.timer.longBreakInterval,
+		LongBreakDue:       a.timer.longBreakDue(now),
+		DailySessionBudget: a.timer.dailySessionBudget,
+		BudgetReached:      a.timer.budgetReached(now),
+		OverBudget:         a.timer.overBudget,
+	}
+	if a.timer.isCounterDay(now) {
+		status.SessionsToday = a.timer.sessionsToday
+		status.FocusSecondsToday = a.timer.focusSecondsToday
+		status.OverBudgetSessionsToday = a.timer.overBudgetSessionsToday
+	}
+	return status
+}
+
+// SessionsSinceLongBreak returns how many work sessions have been completed
+// today since the last long break
+func (a *App) SessionsSinceLongBreak() int {
+	a.timer.mu.RLock()
+	defer a.timer.mu.RUnlock()
+	return a.timer.sessionsSinceLongBreakOn(time.Now())
+}
+
+// sessionsSinceLongBreakOn returns the counter as seen on the given day;
+// a counter recorded on an earlier day reads as zero (must be called with lock held)
+func (t *PomodoroTimer) sessionsSinceLongBreakOn(now time.Time) int {
+	if !t.isCounterDay(now) {
+		return 0
+	}
+	return t.sessionsSinceLongBreak
+}
+
+// isCounterDay reports whether the daily counters belong to the given day
+// (must be called with lock held)
+func (t *PomodoroTimer) isCounterDay(now time.Time) bool {
+	return t.counterDay == now.Format("2006-01-02")
+}
+
+// longBreakDue reports whether the next break should be a long one
+// (must be called with lock held)
+func (t *PomodoroTimer) longBreakDue(now time.Time) bool {
+	return t.longBreakInterval > 0 && t.sessionsSinceLongBreakOn(now) >= t.longBreakInterval
+}
+
+// budgetReached reports whether today's completed sessions have used up the
+// daily budget (must be called with lock held)
+func (t *PomodoroTimer) budgetReached(now time.Time) bool {
+	return t.dailySessionBudget > 0 && t.isCounterDay(now) && t.sessionsToday >= t.dailySessionBudget
+}
+
+// rollOverDay resets the daily counters when a new day has started
+// (must be called with lock held)
+func (t *PomodoroTimer) rollOverDay(now time.Time) {
+	today := now.Format("2006-01-02")
+	if t.counterDay != today {
+		t.counterDay = today
+		t.sessionsSinceLongBreak = 0
+		t.sessionsToday = 0
+		t.focusSecondsToday = 0
+		t.overBudgetSessionsToday = 0
 	}
 }
 
 // startTicking starts the timer countdown
 func (t *PomodoroTimer) startTicking() {
-	if t.ticker != nil {
-		t.ticker.Stop()
-	}
+	t.stopTicking()
 	
-	t.ticker = time.NewTicker(1 * time.Second)
+	ticker := time.NewTicker(1 * time.Second)
+	stop := make(chan bool)
+	t.ticker = ticker
+	t.stopChan = stop
 	
 	go func() {
 		for {
 			select {
-			case <-t.ticker.C:
+			case <-ticker.C:
 				t.mu.Lock()
-				t.remainingTime--
-				
-				if t.remainingTime <= 0 {
-					t.handleTimerComplete()
+				// A stop may have landed while we waited for the lock
+				select {
+				case <-stop:
+					t.mu.Unlock()
+					return
+				default:
 				}
+				t.tick()
 				t.mu.Unlock()
 				
-			case <-t.stopChan:
+			case <-stop:
 				return
 			}
 		}
 	}()
 }
 
+// tick advances the countdown by one second (must be called with lock held)
+func (t *PomodoroTimer) tick() {
+	t.remainingTime--
+	if t.state == StateWorking {
+		t.workedSeconds++
+	}
+	
+	if t.remainingTime <= 0 {
+		t.handleTimerComplete()
+	}
+}
+
 // stopTicking stops the timer countdown
 func (t *PomodoroTimer) stopTicking() {
 	if t.ticker != nil {
@@ -172,9 +301,9 @@ func (t *PomodoroTimer) stopTicking() {
 		t.ticker = nil
 	}
 	
-	select {
-	case t.stopChan <- true:
-	default:
+	if t.stopChan != nil {
+		close(t.stopChan)
+		t.stopChan = nil
 	}
 }
 
@@ -182,10 +311,19 @@ func (t *PomodoroTimer) stopTicking() {
 func (t *PomodoroTimer) handleTimerComplete() {
 	switch t.state {
 	case StateWorking:
+		now := time.Now()
+		t.rollOverDay(now)
 		t.completedPomodoros++
+		t.sessionsSinceLongBreak++
+		t.sessionsToday++
+		t.focusSecondsToday += t.workedSeconds
+		t.workedSeconds = 0
+		if t.overBudget {
+			t.overBudgetSessionsToday++
+		}
 		
 		// Determine break type
-		if t.completedPomodoros%t.longBreakInterval == 0 {
+		if t.longBreakDue(now) {
 			t.state = StateLongBreak
 			t.remainingTime = t.longBreakDuration * 60
 		} else {
@@ -194,24 +332,113 @@ func (t *PomodoroTimer) handleTimerComplete() {
 		}
 		
 	case StateBreak, StateLongBreak:
+		if t.state == StateLongBreak {
+			t.sessionsSinceLongBreak = 0
+		}
+		
+		// Don't roll into another session past the budget unless overridden
+		if t.budgetReached(time.Now()) && !t.overBudget {
+			t.state = StateIdle
+			t.remainingTime = 0
+			t.stopTicking()
+			return
+		}
 		t.state = StateWorking
 		t.currentCycle++
 		t.remainingTime = t.workDuration * 60
 	}
 	
-	// Continue ticking for the next phase
-	t.startTicking()
+	// The running ticker carries on into the next phase
 }
 
-// UpdateSettings updates timer settings
-func (a *App) UpdateSettings(workDuration, shortBreak, longBreak, longBreakInterval int) {
+// UpdateSettings updates timer settings, rejecting durations outside the allowed range
+func (a *App) UpdateSettings(workDuration, shortBreak, longBreak, longBreakInterval int) error {
 	a.timer.mu.Lock()
 	defer a.timer.mu.Unlock()
 	
+	durations := []struct {
+		state   TimerState
+		minutes int
+	}{
+		{StateWorking, workDuration},
+		{StateBreak, shortBreak},
+		{StateLongBreak, longBreak},
+	}
+	for _, d := range durations {
+		if err := a.timer.validateDuration(d.state, d.minutes, a.timer.durationBounds[d.state]); err != nil {
+			return err
+		}
+	}
+	if longBreakInterval < 1 {
+		return fmt.Errorf("long break interval must be at least 1 pomodoro, got %d", longBreakInterval)
+	}
+	
 	a.timer.workDuration = workDuration
 	a.timer.shortBreakDuration = shortBreak
 	a.timer.longBreakDuration = longBreak
 	a.timer.longBreakInterval = longBreakInterval
+	return nil
+}
+
+// SetDailySessionBudget sets how many work sessions may be started per day,
+// 0 to disable the budget
+func (a *App) SetDailySessionBudget(sessions int) error {
+	a.timer.mu.Lock()
+	defer a.timer.mu.Unlock()
+	
+	if sessions < 0 {
+		return fmt.Errorf("daily session budget must not be negative, got %d", sessions)
+	}
+	a.timer.dailySessionBudget = sessions
+	return nil
+}
+
+// SetDurationBounds sets the allowed range, in minutes, for one session type
+// (working, break or longBreak). The range must include the duration in use
+func (a *App) SetDurationBounds(sessionType string, minDuration, maxDuration int) error {
+	a.timer.mu.Lock()
+	defer a.timer.mu.Unlock()
+	
+	state := TimerState(sessionType)
+	if _, ok := durationNames[state]; !ok {
+		return fmt.Errorf("unknown session type %q, expected working, break or longBreak", sessionType)
+	}
+	if minDuration < 1 {
+		return fmt.Errorf("minimum duration must be at least 1 minute, got %d", minDuration)
+	}
+	if minDuration > maxDuration {
+		return fmt.Errorf("minimum duration %d must not exceed maximum duration %d", minDuration, maxDuration)
+	}
+	
+	bounds := durationBounds{min: minDuration, max: maxDuration}
+	if err := a.timer.validateDuration(state, a.timer.durationFor(state), bounds); err != nil {
+		return err
+	}
+	
+	a.timer.durationBounds[state] = bounds
+	return nil
+}
+
+// durationFor returns the configured duration in minutes for a session type
+// (must be called with lock held)
+func (t *PomodoroTimer) durationFor(state TimerState) int {
+	switch state {
+	case StateBreak:
+		return t.shortBreakDuration
+	case StateLongBreak:
+		return t.longBreakDuration
+	default:
+		return t.workDuration
+	}
+}
+
+// validateDuration checks a session type's duration in minutes against bounds
+// (must be called with lock held)
+func (t *PomodoroTimer) validateDuration(state TimerState, minutes int, bounds durationBounds) error {
+	if minutes < bounds.min || minutes > bounds.max {
+		return fmt.Errorf("%s must be between %d and %d minutes, got %d", durationNames[state], bounds.min, bounds.max, minutes)
+	}
+	return nil
 }
 
 // GetSettings returns current timer settings
@@ -224,5 +451,12 @@ func (a *App) GetSettings() map[string]int {
 		"shortBreakDuration": a.timer.shortBreakDuration,
 		"longBreakDuration":  a.timer.longBreakDuration,
 		"longBreakInterval":  a.timer.longBreakInterval,
+		"dailySessionBudget": a.timer.dailySessionBudget,
+		"workMinDuration":       a.timer.durationBounds[StateWorking].min,
+		"workMaxDuration":       a.timer.durationBounds[StateWorking].max,
+		"shortBreakMinDuration": a.timer.durationBounds[StateBreak].min,
+		"shortBreakMaxDuration": a.timer.durationBounds[StateBreak].max,
+		"longBreakMinDuration":  a.timer.durationBounds[StateLongBreak].min,
+		"longBreakMaxDuration":  a.timer.durationBounds[StateLongBreak].max,
 	}
 }
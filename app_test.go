@@ -0,0 +1,350 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+// completeWorkSession runs handleTimerComplete for a finished work phase
+func completeWorkSession(t *PomodoroTimer) {
+	t.state = StateWorking
+	t.handleTimerComplete()
+}
+
+func TestSessionsSinceLongBreak(t *testing.T) {
+	timer := NewPomodoroTimer()
+	now := time.Now()
+
+	for i := 1; i < timer.longBreakInterval; i++ {
+		completeWorkSession(timer)
+		if timer.state != StateBreak {
+			t.Fatalf("session %d: expected short break, got %s", i, timer.state)
+		}
+	}
+	if got := timer.sessionsSinceLongBreakOn(now); got != timer.longBreakInterval-1 {
+		t.Fatalf("expected %d sessions since long break, got %d", timer.longBreakInterval-1, got)
+	}
+	if timer.longBreakDue(now) {
+		t.Fatal("long break should not be due before the interval is reached")
+	}
+
+	completeWorkSession(timer)
+	if timer.state != StateLongBreak {
+		t.Fatalf("expected long break at the interval, got %s", timer.state)
+	}
+	if got := timer.sessionsSinceLongBreakOn(now); got != timer.longBreakInterval {
+		t.Fatalf("expected %d sessions since long break, got %d", timer.longBreakInterval, got)
+	}
+	if !timer.longBreakDue(now) {
+		t.Fatal("long break should be due at the interval")
+	}
+
+	timer.handleTimerComplete()
+	if timer.state != StateWorking {
+		t.Fatalf("expected work after long break, got %s", timer.state)
+	}
+	if got := timer.sessionsSinceLongBreakOn(now); got != 0 {
+		t.Fatalf("expected counter reset after long break, got %d", got)
+	}
+	if timer.longBreakDue(now) {
+		t.Fatal("long break should not be due right after one completes")
+	}
+}
+
+func TestSessionsSinceLongBreakResetsOnNewDay(t *testing.T) {
+	timer := NewPomodoroTimer()
+	now := time.Now()
+
+	completeWorkSession(timer)
+	completeWorkSession(timer)
+	if got := timer.sessionsSinceLongBreakOn(now); got != 2 {
+		t.Fatalf("expected 2 sessions since long break, got %d", got)
+	}
+
+	tomorrow := now.AddDate(0, 0, 1)
+	if got := timer.sessionsSinceLongBreakOn(tomorrow); got != 0 {
+		t.Fatalf("expected stale counter to read as 0, got %d", got)
+	}
+	timer.rollOverDay(tomorrow)
+	if timer.sessionsSinceLongBreak != 0 {
+		t.Fatalf("expected counter reset on new day, got %d", timer.sessionsSinceLongBreak)
+	}
+	if timer.longBreakDue(tomorrow) {
+		t.Fatal("long break should not be due at the start of a new day")
+	}
+}
+
+func TestPausedLongBreakResumesAsLongBreak(t *testing.T) {
+	app := NewApp()
+	defer app.StopTimer()
+
+	app.timer.mu.Lock()
+	for i := 0; i < app.timer.longBreakInterval; i++ {
+		completeWorkSession(app.timer)
+	}
+	app.timer.mu.Unlock()
+
+	if status := app.PauseTimer(); status.State != StatePaused {
+		t.Fatalf("expected paused, got %s", status.State)
+	}
+	if status := app.StartTimer(false); status.State != StateLongBreak {
+		t.Fatalf("expected long break after resume, got %s", status.State)
+	}
+
+	// Finish the break by hand rather than waiting on the real ticker
+	app.timer.mu.Lock()
+	app.timer.stopTicking()
+	app.timer.handleTimerComplete()
+	app.timer.mu.Unlock()
+
+	status := app.GetTimerStatus()
+	if status.State != StateWorking {
+		t.Fatalf("expected work after long break, got %s", status.State)
+	}
+	if status.SessionsSinceLongBreak != 0 {
+		t.Fatalf("expected counter reset after resumed long break, got %d", status.SessionsSinceLongBreak)
+	}
+	if status.CompletedPomodoros != app.timer.longBreakInterval {
+		t.Fatalf("expected resumed break not to count as a pomodoro, got %d", status.CompletedPomodoros)
+	}
+}
+
+// runPhase ticks the timer until the current phase ends
+func runPhase(t *PomodoroTimer) {
+	for phase := t.state; t.state == phase; {
+		t.tick()
+	}
+}
+
+func TestTodayTotalsAccumulate(t *testing.T) {
+	app := NewApp()
+	timer := app.timer
+	timer.state = StateWorking
+	timer.remainingTime = timer.workDuration * 60
+
+	runPhase(timer) // first work session
+	runPhase(timer) // short break
+	runPhase(timer) // second work session
+
+	status := app.getStatus()
+	if status.SessionsToday != 2 {
+		t.Errorf("expected 2 sessions today, got %d", status.SessionsToday)
+	}
+	if want := 2 * timer.workDuration * 60; status.FocusSecondsToday != want {
+		t.Errorf("expected %d focus seconds today, got %d", want, status.FocusSecondsToday)
+	}
+}
+
+func TestBreakTicksDoNotCountAsFocus(t *testing.T) {
+	app := NewApp()
+	timer := app.timer
+	timer.state = StateWorking
+	timer.remainingTime = timer.workDuration * 60
+	runPhase(timer)
+	focus := timer.focusSecondsToday
+
+	// Pause and resume the break half way through, then let it finish
+	for i := 0; i < 60; i++ {
+		timer.tick()
+	}
+	app.PauseTimer()
+	app.StartTimer(false)
+
+	// Stop the real ticker so only the ticks below advance the timer
+	timer.mu.Lock()
+	defer timer.mu.Unlock()
+	timer.stopTicking()
+	runPhase(timer)
+
+	if timer.state != StateWorking {
+		t.Fatalf("expected work after the break, got %s", timer.state)
+	}
+	if timer.focusSecondsToday != focus || timer.sessionsToday != 1 {
+		t.Errorf("break counted as focus: %d sessions, %d seconds", timer.sessionsToday, timer.focusSecondsToday)
+	}
+	if timer.workedSeconds != 0 {
+		t.Errorf("expected no worked seconds carried in from the break, got %d", timer.workedSeconds)
+	}
+}
+
+func TestStopTimerDropsUnfinishedSession(t *testing.T) {
+	app := NewApp()
+	timer := app.timer
+	timer.state = StateWorking
+	timer.remainingTime = timer.workDuration * 60
+	for i := 0; i < 120; i++ {
+		timer.tick()
+	}
+
+	app.StopTimer()
+	if timer.workedSeconds != 0 {
+		t.Fatalf("expected worked seconds dropped on stop, got %d", timer.workedSeconds)
+	}
+
+	timer.state = StateWorking
+	timer.remainingTime = timer.workDuration * 60
+	runPhase(timer)
+	if want := timer.workDuration * 60; timer.focusSecondsToday != want {
+		t.Errorf("expected only the completed session's %d seconds, got %d", want, timer.focusSecondsToday)
+	}
+}
+
+func TestStatusTodayTotalsResetWhenStale(t *testing.T) {
+	app := NewApp()
+	timer := app.timer
+	timer.counterDay = time.Now().AddDate(0, 0, -1).Format("2006-01-02")
+	timer.sessionsToday = 3
+	timer.focusSecondsToday = 4500
+	timer.sessionsSinceLongBreak = 3
+
+	status := app.getStatus()
+	if status.SessionsToday != 0 || status.FocusSecondsToday != 0 || status.SessionsSinceLongBreak != 0 {
+		t.Errorf("expected zeroed daily totals for a stale day, got %+v", status)
+	}
+}
+
+func TestDailySessionBudget(t *testing.T) {
+	app := NewApp()
+	defer app.StopTimer()
+	if err := app.SetDailySessionBudget(2); err != nil {
+		t.Fatalf("SetDailySessionBudget() error = %v", err)
+	}
+	timer := app.timer
+	timer.workDuration = 1
+	timer.shortBreakDuration = 1
+
+	// Start and finish sessions up to the budget without a real ticker
+	app.StartTimer(false)
+	timer.mu.Lock()
+	timer.stopTicking()
+	runPhase(timer) // first work session
+	if status := app.getStatus(); status.BudgetReached {
+		t.Fatal("budget should not be reached after one of two sessions")
+	}
+	runPhase(timer) // short break
+	runPhase(timer) // second work session
+	if status := app.getStatus(); !status.BudgetReached {
+		t.Fatal("expected budget warning once the budget is used up")
+	}
+	runPhase(timer) // short break ends without starting a third session
+	if timer.state != StateIdle {
+		t.Fatalf("expected timer to stop at the budget, got %s", timer.state)
+	}
+	timer.mu.Unlock()
+
+	if status := app.StartTimer(false); status.State != StateIdle || !status.BudgetReached {
+		t.Fatalf("expected start without override to be refused, got %+v", status)
+	}
+
+	status := app.StartTimer(true)
+	if status.State != StateWorking || !status.OverBudget {
+		t.Fatalf("expected override to start a flagged session, got %+v", status)
+	}
+	timer.mu.Lock()
+	timer.stopTicking()
+	runPhase(timer)
+	timer.mu.Unlock()
+
+	status = app.GetTimerStatus()
+	if status.SessionsToday != 3 || status.OverBudgetSessionsToday != 1 {
+		t.Errorf("expected 3 sessions with 1 over budget, got %d and %d", status.SessionsToday, status.OverBudgetSessionsToday)
+	}
+}
+
+func TestSetDailySessionBudgetRejectsNegative(t *testing.T) {
+	app := NewApp()
+	if err := app.SetDailySessionBudget(-1); err == nil {
+		t.Fatal("expected error for a negative budget")
+	}
+	if budget := app.GetSettings()["dailySessionBudget"]; budget != 0 {
+		t.Fatalf("expected budget unchanged, got %d", budget)
+	}
+}
+
+func TestUpdateSettingsBounds(t *testing.T) {
+	tests := []struct {
+		name              string
+		workDuration      int
+		shortBreak        int
+		longBreak         int
+		longBreakInterval int
+		wantErr           bool
+	}{
+		{"minimum durations", 1, 1, 1, 4, false},
+		{"maximum durations", 240, 60, 120, 4, false},
+		{"work below minimum", 0, 5, 15, 4, true},
+		{"work above maximum", 241, 5, 15, 4, true},
+		{"short break below minimum", 25, 0, 15, 4, true},
+		{"short break above its maximum", 25, 61, 15, 4, true},
+		{"240 minute short break", 25, 240, 15, 4, true},
+		{"long break above its maximum", 25, 5, 121, 4, true},
+		{"zero interval", 25, 5, 15, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := NewApp()
+			before := app.GetSettings()
+
+			err := app.UpdateSettings(tt.workDuration, tt.shortBreak, tt.longBreak, tt.longBreakInterval)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UpdateSettings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			after := app.GetSettings()
+			if tt.wantErr {
+				for key, value := range before {
+					if after[key] != value {
+						t.Errorf("%s changed from %d to %d on rejected update", key, value, after[key])
+					}
+				}
+				return
+			}
+			if after["workDuration"] != tt.workDuration || after["longBreakInterval"] != tt.longBreakInterval {
+				t.Errorf("settings not applied: %v", after)
+			}
+		})
+	}
+}
+
+func TestSetDurationBounds(t *testing.T) {
+	app := NewApp()
+	before := app.GetSettings()
+
+	rejected := []struct {
+		name        string
+		sessionType string
+		min, max    int
+	}{
+		{"unknown session type", "meeting", 10, 60},
+		{"minimum below 1 minute", "working", 0, 60},
+		{"minimum above maximum", "working", 30, 20},
+		{"excludes current work duration", "working", 30, 60},
+		{"excludes current short break", "break", 10, 30},
+		{"excludes current long break", "longBreak", 1, 10},
+	}
+	for _, tt := range rejected {
+		if err := app.SetDurationBounds(tt.sessionType, tt.min, tt.max); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+	after := app.GetSettings()
+	for key, value := range before {
+		if after[key] != value {
+			t.Errorf("%s changed from %d to %d on rejected bounds", key, value, after[key])
+		}
+	}
+
+	if err := app.SetDurationBounds("working", 10, 60); err != nil {
+		t.Fatalf("SetDurationBounds() error = %v", err)
+	}
+	if settings := app.GetSettings(); settings["workMinDuration"] != 10 || settings["workMaxDuration"] != 60 {
+		t.Fatalf("bounds not reported by GetSettings: %v", settings)
+	}
+	if err := app.UpdateSettings(61, 5, 15, 4); err == nil {
+		t.Error("expected error above the new work maximum")
+	}
+	if err := app.UpdateSettings(60, 10, 15, 4); err != nil {
+		t.Errorf("expected durations within the new bounds to be accepted, got %v", err)
+	}
+}
@@ -8,7 +8,13 @@ export function GetTimerStatus():Promise<main.TimerStatus>;
 
 export function PauseTimer():Promise<main.TimerStatus>;
 
-export function StartTimer():Promise<main.TimerStatus>;
+export function SessionsSinceLongBreak():Promise<number>;
+
+export function SetDailySessionBudget(arg1:number):Promise<void>;
+
+export function SetDurationBounds(arg1:string,arg2:number,arg3:number):Promise<void>;
+
+export function StartTimer(arg1:boolean):Promise<main.TimerStatus>;
 
 export function StopTimer():Promise<main.TimerStatus>;
 
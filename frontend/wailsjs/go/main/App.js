@@ -14,8 +14,20 @@ export function PauseTimer() {
   return window['go']['main']['App']['PauseTimer']();
 }
 
-export function StartTimer() {
-  return window['go']['main']['App']['StartTimer']();
+export function SessionsSinceLongBreak() {
+  return window['go']['main']['App']['SessionsSinceLongBreak']();
+}
+
+export function SetDailySessionBudget(arg1) {
+  return window['go']['main']['App']['SetDailySessionBudget'](arg1);
+}
+
+export function SetDurationBounds(arg1, arg2, arg3) {
+  return window['go']['main']['App']['SetDurationBounds'](arg1, arg2, arg3);
+}
+
+export function StartTimer(arg1) {
+  return window['go']['main']['App']['StartTimer'](arg1);
 }
 
 export function StopTimer() {
@@ -5,6 +5,15 @@ export namespace main {
 	    remainingTime: number;
 	    currentCycle: number;
 	    completedPomodoros: number;
+	    sessionsSinceLongBreak: number;
+	    longBreakInterval: number;
+	    longBreakDue: boolean;
+	    sessionsToday: number;
+	    focusSecondsToday: number;
+	    dailySessionBudget: number;
+	    budgetReached: boolean;
+	    overBudget: boolean;
+	    overBudgetSessionsToday: number;
 	
 	    static createFrom(source: any = {}) {
 	        return new TimerStatus(source);
@@ -16,6 +25,15 @@ export namespace main {
 	        this.remainingTime = source["remainingTime"];
 	        this.currentCycle = source["currentCycle"];
 	        this.completedPomodoros = source["completedPomodoros"];
+	        this.sessionsSinceLongBreak = source["sessionsSinceLongBreak"];
+	        this.longBreakInterval = source["longBreakInterval"];
+	        this.longBreakDue = source["longBreakDue"];
+	        this.sessionsToday = source["sessionsToday"];
+	        this.focusSecondsToday = source["focusSecondsToday"];
+	        this.dailySessionBudget = source["dailySessionBudget"];
+	        this.budgetReached = source["budgetReached"];
+	        this.overBudget = source["overBudget"];
+	        this.overBudgetSessionsToday = source["overBudgetSessionsToday"];
 	    }
 	}
 